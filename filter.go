@@ -0,0 +1,84 @@
+package log15
+
+// DefaultFuzz is the value substituted for a matched key or value by
+// FilterKey and FilterValue when no explicit fuzz string is requested.
+const DefaultFuzz = "***"
+
+// FilterKey returns a Handler that wraps h and replaces the value of any
+// context key matching one of keys with DefaultFuzz before the Record
+// reaches h. It's a quick way to redact well-known sensitive keys (e.g.
+// "password", "token") without writing a custom Handler.
+func FilterKey(h Handler, keys ...string) Handler {
+	return FilterKeyWithFuzz(h, DefaultFuzz, keys...)
+}
+
+// FilterKeyWithFuzz is like FilterKey but lets the caller choose the
+// replacement string instead of DefaultFuzz.
+//
+// A Record is shared with any sibling branch of a MultiHandler/
+// FailoverHandler, so the substitution is made on a copy of r (and its Ctx)
+// rather than in place — otherwise redacting on one branch would also
+// redact the value seen by every other branch.
+func FilterKeyWithFuzz(h Handler, fuzz string, keys ...string) Handler {
+	return FuncHandler(func(r *Record) error {
+		cp := *r
+		cp.Ctx = append([]interface{}(nil), r.Ctx...)
+		for i := 0; i < len(cp.Ctx); i += 2 {
+			k, ok := cp.Ctx[i].(string)
+			if !ok {
+				continue
+			}
+			for _, key := range keys {
+				if k == key {
+					cp.Ctx[i+1] = fuzz
+					break
+				}
+			}
+		}
+		return h.Log(&cp)
+	})
+}
+
+// FilterValue returns a Handler that wraps h and replaces any context value
+// equal to one of values with DefaultFuzz before the Record reaches h.
+func FilterValue(h Handler, values ...string) Handler {
+	return FilterValueWithFuzz(h, DefaultFuzz, values...)
+}
+
+// FilterValueWithFuzz is like FilterValue but lets the caller choose the
+// replacement string instead of DefaultFuzz.
+//
+// As with FilterKeyWithFuzz, the substitution is made on a copy of r (and
+// its Ctx) so that redacting on this branch never affects a Record shared
+// with sibling branches of a MultiHandler/FailoverHandler.
+func FilterValueWithFuzz(h Handler, fuzz string, values ...string) Handler {
+	return FuncHandler(func(r *Record) error {
+		cp := *r
+		cp.Ctx = append([]interface{}(nil), r.Ctx...)
+		for i := 1; i < len(cp.Ctx); i += 2 {
+			v, ok := cp.Ctx[i].(string)
+			if !ok {
+				continue
+			}
+			for _, value := range values {
+				if v == value {
+					cp.Ctx[i] = fuzz
+					break
+				}
+			}
+		}
+		return h.Log(&cp)
+	})
+}
+
+// FilterFunc returns a Handler that wraps h and drops any Record for which
+// fn returns true, so callers can implement per-module verbosity or any
+// other custom predicate without writing a custom Handler.
+func FilterFunc(h Handler, fn func(lvl Lvl, ctx []interface{}) bool) Handler {
+	return FuncHandler(func(r *Record) error {
+		if fn(r.Lvl, r.Ctx) {
+			return nil
+		}
+		return h.Log(r)
+	})
+}
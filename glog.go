@@ -0,0 +1,153 @@
+package log15
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-stack/stack"
+)
+
+// glogRule pairs a glob matched against a call-site file path with the max
+// Lvl that subsystem is allowed to log at.
+type glogRule struct {
+	glob string
+	lvl  Lvl
+}
+
+// GlogHandler wraps a Handler and applies go-ethereum-style Vmodule
+// verbosity overrides: a glob matched against the call-site file path can
+// raise or lower the effective max level for that subsystem alone, without
+// touching the global level used everywhere else.
+type GlogHandler struct {
+	mu        sync.RWMutex
+	h         Handler
+	verbosity Lvl
+	rules     []glogRule
+}
+
+// NewGlogHandler returns a GlogHandler wrapping h. Until Vmodule or
+// Verbosity is called it behaves exactly like h.
+func NewGlogHandler(h Handler) *GlogHandler {
+	return &GlogHandler{h: h, verbosity: LvlTrace}
+}
+
+// Verbosity sets the baseline max level used for call sites that don't match
+// any Vmodule rule.
+func (g *GlogHandler) Verbosity(lvl Lvl) {
+	g.mu.Lock()
+	g.verbosity = lvl
+	g.mu.Unlock()
+}
+
+// clone returns a new, unwrapped GlogHandler carrying a copy of g's current
+// rules and verbosity, for a Logger to fork when it creates a child via New
+// — so the child can call Vmodule without mutating the rules its parent (or
+// any sibling) consults. A nil g clones to a fresh, empty GlogHandler.
+func (g *GlogHandler) clone() *GlogHandler {
+	if g == nil {
+		return NewGlogHandler(nil)
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return &GlogHandler{
+		verbosity: g.verbosity,
+		rules:     append([]glogRule(nil), g.rules...),
+	}
+}
+
+// Vmodule parses a glog-style spec, e.g. "p2p/*=5,consensus=3", into a set
+// of {glob, Lvl} rules checked against the call-site file path before the
+// level gate. An empty spec clears all overrides.
+func (g *GlogHandler) Vmodule(spec string) error {
+	rules, err := parseVmodule(spec)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	g.rules = rules
+	g.mu.Unlock()
+	return nil
+}
+
+// parseVmodule turns a comma-separated "glob=level" spec into glogRules.
+func parseVmodule(spec string) ([]glogRule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(spec, ",")
+	rules := make([]glogRule, 0, len(parts))
+	for _, part := range parts {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid vmodule rule %q", part)
+		}
+		lvl, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid vmodule level in %q: %v", part, err)
+		}
+		rules = append(rules, glogRule{glob: kv[0], lvl: Lvl(lvl)})
+	}
+	return rules, nil
+}
+
+// match returns the Lvl of the first Vmodule rule whose glob matches call,
+// or ok=false if no rule applies (including when g is nil or has no rules).
+// A glob with no "/" is matched against the call site's package directory
+// alone, so a spec like "consensus=3" matches any file in a "consensus"
+// package without needing a wildcard; a glob containing "/" is matched
+// against "<package>/<file>", so "p2p/*=5" matches any file directly under
+// p2p/.
+func (g *GlogHandler) match(call stack.Call) (Lvl, bool) {
+	if g == nil {
+		return 0, false
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if len(g.rules) == 0 {
+		return 0, false
+	}
+
+	file := fmt.Sprintf("%+s", call)
+	dir, base := path.Split(file)
+	_, pkg := path.Split(strings.TrimSuffix(dir, "/"))
+
+	for _, rule := range g.rules {
+		var ok bool
+		if strings.Contains(rule.glob, "/") {
+			ok, _ = path.Match(rule.glob, path.Join(pkg, base))
+		} else {
+			ok, _ = path.Match(rule.glob, pkg)
+		}
+		if ok {
+			return rule.lvl, true
+		}
+	}
+
+	return 0, false
+}
+
+// Log applies the Vmodule rules to r.Call, falling back to Verbosity when no
+// rule matches, then forwards to the wrapped Handler if the record survives.
+// This lets GlogHandler be composed directly onto any Handler chain for
+// callers who aren't using the root logger's Vmodule convenience.
+func (g *GlogHandler) Log(r *Record) error {
+	maxLvl, ok := g.match(r.Call)
+	if !ok {
+		g.mu.RLock()
+		maxLvl = g.verbosity
+		g.mu.RUnlock()
+	}
+
+	if r.Lvl > maxLvl {
+		return nil
+	}
+	return g.h.Log(r)
+}
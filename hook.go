@@ -0,0 +1,62 @@
+package log15
+
+// Hook taps into a Logger as a side effect of writing a Record. Hooks run
+// after the Record has been dispatched to the Handler, so a failing or slow
+// Hook never prevents a line from being logged.
+type Hook interface {
+	// Levels returns the set of Lvls this Hook wants to see.
+	Levels() []Lvl
+
+	// Fire is called for every Record whose Lvl is in Levels(). An error
+	// returned here is reported through the logger's errorKey channel, not
+	// propagated to the caller that emitted the Record.
+	Fire(r *Record) error
+}
+
+// CounterHook is a Hook that increments a caller-supplied counter for every
+// Record it sees, useful for wiring up level-based metrics (e.g. a Prometheus
+// counter vector keyed by level) without touching the Handler chain.
+type CounterHook struct {
+	Lvls []Lvl
+	Inc  func(Lvl)
+}
+
+// NewCounterHook returns a Hook that calls inc once per matching Record. If
+// lvls is empty the Hook fires for every level.
+func NewCounterHook(inc func(Lvl), lvls ...Lvl) *CounterHook {
+	return &CounterHook{Lvls: lvls, Inc: inc}
+}
+
+func (h *CounterHook) Levels() []Lvl {
+	if len(h.Lvls) > 0 {
+		return h.Lvls
+	}
+	return []Lvl{LvlCrit, LvlError, LvlWarn, LvlInfo, LvlDebug, LvlTrace}
+}
+
+func (h *CounterHook) Fire(r *Record) error {
+	h.Inc(r.Lvl)
+	return nil
+}
+
+// ErrorCaptureHook is a Hook that forwards LvlError and LvlCrit Records to an
+// external error-reporting callback, e.g. to ship them to Sentry or Rollbar
+// without wrapping the Handler.
+type ErrorCaptureHook struct {
+	Capture func(r *Record)
+}
+
+// NewErrorCaptureHook returns a Hook that calls capture for every Record at
+// LvlError or LvlCrit.
+func NewErrorCaptureHook(capture func(r *Record)) *ErrorCaptureHook {
+	return &ErrorCaptureHook{Capture: capture}
+}
+
+func (h *ErrorCaptureHook) Levels() []Lvl {
+	return []Lvl{LvlError, LvlCrit}
+}
+
+func (h *ErrorCaptureHook) Fire(r *Record) error {
+	h.Capture(r)
+	return nil
+}
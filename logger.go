@@ -2,6 +2,7 @@ package log15
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/go-stack/stack"
@@ -22,11 +23,14 @@ const (
 	LvlWarn
 	LvlInfo
 	LvlDebug
+	LvlTrace
 )
 
 // Returns the name of a Lvl
 func (l Lvl) String() string {
 	switch l {
+	case LvlTrace:
+		return "trce"
 	case LvlDebug:
 		return "dbug"
 	case LvlInfo:
@@ -42,10 +46,34 @@ func (l Lvl) String() string {
 	}
 }
 
+// AlignedString returns the name of a Lvl padded to a fixed width of 5
+// characters, uppercased, so that log levels line up in a column when
+// printed one per line (e.g. "TRACE", "INFO ", "CRIT ").
+func (l Lvl) AlignedString() string {
+	switch l {
+	case LvlTrace:
+		return "TRACE"
+	case LvlDebug:
+		return "DEBUG"
+	case LvlInfo:
+		return "INFO "
+	case LvlWarn:
+		return "WARN "
+	case LvlError:
+		return "ERROR"
+	case LvlCrit:
+		return "CRIT "
+	default:
+		panic("bad level")
+	}
+}
+
 // LvlFromString returns the appropriate Lvl from a string name.
 // Useful for parsing command line args and configuration files.
 func LvlFromString(lvlString string) (Lvl, error) {
 	switch lvlString {
+	case "trace", "trce":
+		return LvlTrace, nil
 	case "debug", "dbug":
 		return LvlDebug, nil
 	case "info":
@@ -92,7 +120,20 @@ type Logger interface {
 	// SetLevel updates the logger to set specific max level to write for
 	SetLevel(maxLvl Lvl)
 
+	// AddHook registers a Hook that is fired for every Record whose level is
+	// one of the Lvls the Hook declares, after the Handler has dispatched it.
+	AddHook(hook Hook)
+
+	// Vmodule installs glog-style per-subsystem verbosity overrides, e.g.
+	// "p2p/*=5,consensus=3", keyed off the call-site file path. Each Logger
+	// owns its own rule set: a child forks a copy of its parent's rules when
+	// created via New, so calling Vmodule on one Logger never affects its
+	// parent or siblings.
+	Vmodule(spec string) error
+
 	// Log a message at the given level with context key/value pairs
+	Trace(msg interface{}, ctx ...interface{})
+	Tracef(format string, args ...interface{})
 	Debug(msg interface{}, ctx ...interface{})
 	Debugf(format string, args ...interface{})
 	Info(msg interface{}, ctx ...interface{})
@@ -111,22 +152,66 @@ type logger struct {
 	maxLvl Lvl
 	ctx []interface{}
 	h   *swapHandler
+	hooks []Hook
+	keyNames RecordKeyNames
+	vmod *GlogHandler
+}
+
+// defaultKeyNames are the RecordKeyNames used by loggers created without an
+// explicit set, i.e. everything built through New/NewWithLvl.
+var defaultKeyNames = RecordKeyNames{
+	Time: timeKey,
+	Msg:  msgKey,
+	Lvl:  lvlKey,
 }
 
 func (l *logger) write(msg string, lvl Lvl, ctx []interface{}) {
-	if lvl <= l.maxLvl {
-		l.h.Log(&Record{
-			Time: time.Now(),
-			Lvl:  lvl,
-			Msg:  msg,
-			Ctx:  newContext(l.ctx, ctx),
-			Call: stack.Caller(2),
-			KeyNames: RecordKeyNames{
-				Time: timeKey,
-				Msg:  msgKey,
-				Lvl:  lvlKey,
-			},
-		})
+	call := stack.Caller(2)
+	maxLvl := l.maxLvl
+	if m, ok := l.vmod.match(call); ok {
+		maxLvl = m
+	}
+
+	if lvl <= maxLvl {
+		r := &Record{
+			Time:     time.Now(),
+			Lvl:      lvl,
+			Msg:      msg,
+			Ctx:      evaluateLazy(newContext(l.ctx, ctx)),
+			Call:     call,
+			KeyNames: l.keyNames,
+		}
+		l.h.Log(r)
+		l.fireHooks(r)
+	}
+}
+
+// fireHooks runs every registered Hook whose Levels() includes r.Lvl. A Hook
+// that returns an error does not stop the others from running; the error is
+// reported as its own Record via errorKey instead of propagating up to the
+// caller that triggered the original log line.
+func (l *logger) fireHooks(r *Record) {
+	for _, hook := range l.hooks {
+		fires := false
+		for _, lvl := range hook.Levels() {
+			if lvl == r.Lvl {
+				fires = true
+				break
+			}
+		}
+		if !fires {
+			continue
+		}
+		if err := hook.Fire(r); err != nil {
+			l.h.Log(&Record{
+				Time:     time.Now(),
+				Lvl:      LvlError,
+				Msg:      "hook fire failed",
+				Ctx:      []interface{}{errorKey, err},
+				Call:     r.Call,
+				KeyNames: r.KeyNames,
+			})
+		}
 	}
 }
 
@@ -134,7 +219,7 @@ func (l *logger) New(lvl Lvl, ctx ...interface{}) Logger {
 	if lvl == 0 {
 		lvl = l.maxLvl
 	}
-	child := &logger{lvl,newContext(l.ctx, ctx), new(swapHandler)}
+	child := &logger{lvl, newContext(l.ctx, ctx), new(swapHandler), l.hooks, l.keyNames, l.vmod.clone()}
 	child.SetHandler(l.h)
 	return child
 }
@@ -147,6 +232,15 @@ func newContext(prefix []interface{}, suffix []interface{}) []interface{} {
 	return newCtx
 }
 
+func (l *logger) Trace(msg interface{}, ctx ...interface{}) {
+	l.write(fmt.Sprint(msg), LvlTrace, ctx)
+}
+
+func (l *logger) Tracef(format string, args ...interface{}) {
+	var emptyCtx []interface{}
+	l.write(fmt.Sprintf(format, args...), LvlTrace, emptyCtx)
+}
+
 func (l *logger) Debug(msg interface{}, ctx ...interface{}) {
 	l.write(fmt.Sprint(msg), LvlDebug, ctx)
 }
@@ -221,6 +315,18 @@ func (l *logger) SetLevel(maxLvl Lvl) {
 	l.maxLvl = maxLvl
 }
 
+func (l *logger) AddHook(hook Hook) {
+	l.hooks = append(l.hooks, hook)
+}
+
+// Vmodule parses spec into l's own Vmodule rule set, consulted by write via
+// l.vmod.match before the usual SetLevel gate. It does not touch l's Handler
+// or maxLvl. l.vmod is forked per Logger at New() time, so this never
+// affects l's parent or siblings.
+func (l *logger) Vmodule(spec string) error {
+	return l.vmod.Vmodule(spec)
+}
+
 func normalize(ctx []interface{}) []interface{} {
 	// if the caller passed a Ctx object, then expand it
 	if len(ctx) == 1 {
@@ -254,6 +360,69 @@ type Lazy struct {
 	Fn interface{}
 }
 
+// evaluateLazy walks ctx, a flat key/value slice, and replaces any value of
+// type Lazy with the result of invoking its Fn. This only runs once a Record
+// has already passed the level gate in write, so the deferred computation is
+// never paid for filtered-out records. Every entry evaluateLazy appends is in
+// key/value pairs, so a multi-return Fn never shifts the keys that follow it
+// in ctx: the first return value keeps the original key, and each additional
+// return value gets its own synthesized "<key>_<n>" key. A Fn that isn't a
+// no-argument func reports an errorKey value instead of panicking.
+func evaluateLazy(ctx []interface{}) []interface{} {
+	out := make([]interface{}, 0, len(ctx))
+	for i := 0; i < len(ctx); i += 2 {
+		key, val := ctx[i], ctx[i+1]
+		lz, ok := val.(Lazy)
+		if !ok {
+			out = append(out, key, val)
+			continue
+		}
+
+		results, err := callLazy(lz.Fn)
+		if err != nil {
+			out = append(out, key, nil, errorKey, err)
+			continue
+		}
+
+		out = append(out, key, results[0])
+		for n := 1; n < len(results); n++ {
+			out = append(out, fmt.Sprintf("%v_%d", key, n), results[n])
+		}
+	}
+
+	return out
+}
+
+// callLazy invokes fn, which must be a func with no arguments, via
+// reflection and returns its results as a plain slice. A single-value return
+// becomes a single element; a multi-value return becomes one element per
+// return value; a zero-value return becomes a single nil element. It never
+// panics on a bad fn: a non-func fn, a nil func, or a func that takes
+// arguments yields an error instead.
+func callLazy(fn interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return nil, fmt.Errorf("log15: Lazy.Fn must be a func, got %T", fn)
+	}
+	if v.Type().NumIn() != 0 {
+		return nil, fmt.Errorf("log15: Lazy.Fn must take no arguments, got %d", v.Type().NumIn())
+	}
+	if v.IsNil() {
+		return nil, fmt.Errorf("log15: Lazy.Fn is a nil %T", fn)
+	}
+
+	results := v.Call(nil)
+	if len(results) == 0 {
+		return []interface{}{nil}, nil
+	}
+
+	out := make([]interface{}, len(results))
+	for i, res := range results {
+		out[i] = res.Interface()
+	}
+	return out, nil
+}
+
 // Ctx is a map of key/value pairs to pass as context to a log function
 // Use this only if you really need greater safety around the arguments you pass
 // to the logging functions.
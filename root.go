@@ -24,7 +24,7 @@ func init() {
 		StderrHandler = StreamHandler(colorable.NewColorableStderr(), TerminalFormat())
 	}
 
-	root = &logger{LvlDebug,[]interface{}{}, new(swapHandler)}
+	root = &logger{LvlDebug, []interface{}{}, new(swapHandler), nil, defaultKeyNames, NewGlogHandler(nil)}
 	root.SetHandler(StdoutHandler)
 }
 
@@ -42,10 +42,31 @@ func NewWithLvl(maxLvl Lvl, ctx ...interface{}) Logger {
 	return l
 }
 
+// NewLoggerWithKeyNames returns a new logger with the given context whose
+// Records use keyNames instead of the default "t"/"lvl"/"msg" keys, so the
+// output can be made to match ingestion conventions (ELK, Loki, ...) without
+// touching every Format call site.
+func NewLoggerWithKeyNames(keyNames RecordKeyNames, ctx ...interface{}) Logger {
+	l := root.New(ctx...).(*logger)
+	l.keyNames = keyNames
+	l.SetLevel(root.maxLvl)
+	return l
+}
+
 func SetLevel(maxLvl Lvl) {
 	root.SetLevel(maxLvl)
 }
 
+// AddHook is a convenient alias for Root().AddHook
+func AddHook(hook Hook) {
+	root.AddHook(hook)
+}
+
+// Vmodule is a convenient alias for Root().Vmodule
+func Vmodule(spec string) error {
+	return root.Vmodule(spec)
+}
+
 // Root returns the root logger
 func Root() Logger {
 	return root
@@ -55,6 +76,17 @@ func Root() Logger {
 // etc.) to keep the call depth the same for all paths to logger.write so
 // runtime.Caller(2) always refers to the call site in client code.
 
+// Trace is a convenient alias for Root().Trace
+func Trace(msg string, ctx ...interface{}) {
+	root.write(msg, LvlTrace, ctx)
+}
+
+// mimics logrus.Tracef() behaivour
+func Tracef(format string, args ...interface{}) {
+	var emptyCtx []interface{}
+	root.write(fmt.Sprintf(format, args...), LvlTrace, emptyCtx)
+}
+
 // Debug is a convenient alias for Root().Debug
 func Debug(msg string, ctx ...interface{}) {
 	root.write(msg, LvlDebug, ctx)